@@ -0,0 +1,275 @@
+// Package analytics computes rolling order-flow metrics — aggressor
+// imbalance (OFI), VPIN and micro-price — from an orderbook.OrderBook's
+// match and L2 delta streams.
+package analytics
+
+import (
+	"sync"
+
+	"github.com/oluwadamilarey/crypto-trading-engine/orderbook"
+)
+
+// Snapshot is a point-in-time read of a FlowMeter's metrics.
+type Snapshot struct {
+	OFI        float64
+	VPIN       float64
+	MicroPrice float64
+}
+
+const (
+	tradeRingSize  = 4096
+	bucketRingSize = 50
+)
+
+// trade is a single signed fill recorded into the ring buffer: positive size
+// for a buy-aggressor fill, negative for a sell-aggressor fill.
+type trade struct {
+	signedSize float64
+}
+
+// FlowMeter consumes a single OrderBook's match and L2 streams and maintains
+// rolling order-flow metrics over fixed-size ring buffers, so its memory
+// footprint stays bounded regardless of how long it runs.
+//
+//   - OFI (order flow imbalance) is the signed volume imbalance,
+//     (buyVol-sellVol)/(buyVol+sellVol), over the trades currently held in
+//     the fixed-size trade ring. It has no caller-configurable lookback: the
+//     ring always holds the last tradeRingSize trades.
+//   - VPIN (volume-synchronized probability of informed trading) buckets
+//     trades into fixed-volume clips of size vpinWindow and reports the
+//     average absolute imbalance per clip over the last bucketRingSize
+//     clips.
+//   - MicroPrice is the resting-size-weighted price between the best bid and
+//     best ask, refreshed on every book update.
+type FlowMeter struct {
+	mu sync.RWMutex
+
+	ob         *orderbook.OrderBook
+	vpinWindow float64
+
+	trades    [tradeRingSize]trade
+	tradeHead int
+	tradeLen  int
+
+	bucketVolumes [bucketRingSize]float64
+	bucketHead    int
+	bucketLen     int
+	openBucket    float64
+	openVolume    float64
+
+	bidPrice, bidSize float64
+	askPrice, askSize float64
+
+	ticks chan Snapshot
+}
+
+// NewFlowMeter starts consuming ob's match and book-update streams and
+// returns a FlowMeter tracking OFI over a fixed trade-count ring and VPIN
+// over clips of vpinWindow volume. vpinWindow has no effect on OFI, which is
+// never caller-configurable.
+func NewFlowMeter(ob *orderbook.OrderBook, vpinWindow float64) *FlowMeter {
+	fm := &FlowMeter{
+		ob:         ob,
+		vpinWindow: vpinWindow,
+		ticks:      make(chan Snapshot, 256),
+	}
+
+	go fm.consumeMatches(ob.SubscribeMatches())
+	go fm.consumeBookUpdates(ob.Subscribe())
+
+	return fm
+}
+
+// Ticks returns a channel of Snapshots, one per match or book update
+// processed. The channel is buffered; slow consumers drop ticks rather than
+// block the meter.
+func (fm *FlowMeter) Ticks() <-chan Snapshot {
+	return fm.ticks
+}
+
+func (fm *FlowMeter) consumeMatches(matches <-chan orderbook.Match) {
+	for m := range matches {
+		fm.mu.Lock()
+		fm.recordTrade(aggressorSignedSize(m))
+		snap := fm.snapshotLocked()
+		fm.mu.Unlock()
+
+		fm.emit(snap)
+	}
+}
+
+func (fm *FlowMeter) consumeBookUpdates(updates <-chan orderbook.BookUpdate) {
+	for range updates {
+		fm.mu.Lock()
+		fm.refreshTopOfBook()
+		snap := fm.snapshotLocked()
+		fm.mu.Unlock()
+
+		fm.emit(snap)
+	}
+}
+
+// aggressorSignedSize infers the aggressor side from which order in the
+// match rested first: the order with the later timestamp crossed into the
+// book and is the aggressor. Returns the fill size signed positive for a
+// buy-aggressor, negative for a sell-aggressor. Timestamp is set when an
+// order is created, not when it is submitted, so an order created before
+// the one it trades against (e.g. a promoted stop) is counted on the wrong
+// side.
+func aggressorSignedSize(m orderbook.Match) float64 {
+	size := m.SizeFilled.Float64()
+	if m.Bid.Timestamp > m.Ask.Timestamp {
+		return size
+	}
+	return -size
+}
+
+// recordTrade appends signedSize to the trade ring and folds it into the
+// open VPIN bucket, closing the bucket once it reaches vpinWindow volume.
+// Callers must hold fm.mu.
+func (fm *FlowMeter) recordTrade(signedSize float64) {
+	fm.trades[fm.tradeHead] = trade{signedSize: signedSize}
+	fm.tradeHead = (fm.tradeHead + 1) % tradeRingSize
+	if fm.tradeLen < tradeRingSize {
+		fm.tradeLen++
+	}
+
+	if fm.vpinWindow <= 0 {
+		return
+	}
+
+	remaining := signedSize
+	for remaining != 0 {
+		size := remaining
+		if absFloat(size) > fm.vpinWindow-fm.openVolume {
+			if remaining > 0 {
+				size = fm.vpinWindow - fm.openVolume
+			} else {
+				size = -(fm.vpinWindow - fm.openVolume)
+			}
+		}
+
+		fm.openBucket += size
+		fm.openVolume += absFloat(size)
+		remaining -= size
+
+		if fm.openVolume >= fm.vpinWindow {
+			fm.closeBucket()
+		}
+	}
+}
+
+func (fm *FlowMeter) closeBucket() {
+	fm.bucketVolumes[fm.bucketHead] = absFloat(fm.openBucket)
+	fm.bucketHead = (fm.bucketHead + 1) % bucketRingSize
+	if fm.bucketLen < bucketRingSize {
+		fm.bucketLen++
+	}
+
+	fm.openBucket = 0
+	fm.openVolume = 0
+}
+
+// refreshTopOfBook re-reads the book's current best bid and ask, used to
+// keep MicroPrice accurate regardless of which level a given delta touched.
+// Callers must hold fm.mu.
+func (fm *FlowMeter) refreshTopOfBook() {
+	if bid := fm.ob.BestBid(); bid != nil {
+		fm.bidPrice, fm.bidSize = bid.Price.Float64(), bid.TotalVolume.Float64()
+	} else {
+		fm.bidPrice, fm.bidSize = 0, 0
+	}
+	if ask := fm.ob.BestAsk(); ask != nil {
+		fm.askPrice, fm.askSize = ask.Price.Float64(), ask.TotalVolume.Float64()
+	} else {
+		fm.askPrice, fm.askSize = 0, 0
+	}
+}
+
+// OFI returns the aggressor volume imbalance, (buyVol-sellVol)/(buyVol+
+// sellVol), over the trades currently held in the ring buffer, in [-1, 1];
+// positive means buy-side aggression dominates. Its lookback is always the
+// fixed tradeRingSize-trade ring; vpinWindow only bounds VPIN's volume
+// clips.
+func (fm *FlowMeter) OFI() float64 {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+
+	return fm.ofiLocked()
+}
+
+func (fm *FlowMeter) ofiLocked() float64 {
+	var net, total float64
+	for i := 0; i < fm.tradeLen; i++ {
+		size := fm.trades[i].signedSize
+		net += size
+		total += absFloat(size)
+	}
+	if total == 0 {
+		return 0
+	}
+	return net / total
+}
+
+// VPIN returns the average absolute imbalance per volume bucket over the
+// buckets currently held in the ring buffer, in [0, 1] once at least one
+// full bucket of vpinWindow volume has traded.
+func (fm *FlowMeter) VPIN() float64 {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+
+	return fm.vpinLocked()
+}
+
+func (fm *FlowMeter) vpinLocked() float64 {
+	if fm.bucketLen == 0 || fm.vpinWindow <= 0 {
+		return 0
+	}
+
+	var sum float64
+	for i := 0; i < fm.bucketLen; i++ {
+		sum += fm.bucketVolumes[i]
+	}
+	return sum / (float64(fm.bucketLen) * fm.vpinWindow)
+}
+
+// MicroPrice returns the resting-size-weighted price between the best bid
+// and best ask, which leans toward whichever side carries less resting
+// size. Returns 0 if either side of the book is currently empty.
+func (fm *FlowMeter) MicroPrice() float64 {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+
+	return fm.microPriceLocked()
+}
+
+func (fm *FlowMeter) microPriceLocked() float64 {
+	total := fm.bidSize + fm.askSize
+	if total == 0 {
+		return 0
+	}
+	return (fm.bidPrice*fm.askSize + fm.askPrice*fm.bidSize) / total
+}
+
+func (fm *FlowMeter) snapshotLocked() Snapshot {
+	return Snapshot{
+		OFI:        fm.ofiLocked(),
+		VPIN:       fm.vpinLocked(),
+		MicroPrice: fm.microPriceLocked(),
+	}
+}
+
+func (fm *FlowMeter) emit(snap Snapshot) {
+	select {
+	case fm.ticks <- snap:
+	default:
+		// Slow consumer; drop the tick rather than block the meter.
+	}
+}
+
+func absFloat(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
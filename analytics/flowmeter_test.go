@@ -0,0 +1,111 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oluwadamilarey/crypto-trading-engine/fixedpoint"
+	"github.com/oluwadamilarey/crypto-trading-engine/orderbook"
+)
+
+func fp(f float64) fixedpoint.Value {
+	return fixedpoint.FromFloat(f)
+}
+
+func TestFlowMeterMicroPriceLeansTowardThinnerSide(t *testing.T) {
+	ob := orderbook.NewOrderBook()
+	mustSubmit(t, ob, orderbook.NewLimitOrder(true, fp(10), fp(9_900), orderbook.GTC))
+	mustSubmit(t, ob, orderbook.NewLimitOrder(false, fp(1), fp(10_100), orderbook.GTC))
+
+	fm := NewFlowMeter(ob, 100)
+
+	mid := (9_900.0 + 10_100.0) / 2
+	micro := waitForCondition(t, func() (float64, bool) {
+		m := fm.MicroPrice()
+		return m, m != 0
+	})
+	if micro <= mid || micro >= 10_100 {
+		t.Errorf("expected micro-price to lean toward the thinner ask above the midpoint %v, got %v", mid, micro)
+	}
+}
+
+func TestFlowMeterOFITracksAggressorImbalance(t *testing.T) {
+	ob := orderbook.NewOrderBook()
+	mustSubmit(t, ob, orderbook.NewLimitOrder(false, fp(20), fp(10_000), orderbook.GTC))
+
+	fm := NewFlowMeter(ob, 1_000)
+	mustSubmit(t, ob, orderbook.NewMarketOrder(true, fp(5)))
+
+	ofi := waitForCondition(t, func() (float64, bool) {
+		v := fm.OFI()
+		return v, v != 0
+	})
+	if ofi != 1 {
+		t.Errorf("expected a fully one-sided buy-aggressor fill to report OFI 1, got %v", ofi)
+	}
+}
+
+func TestFlowMeterOFINormalizesByTotalVolume(t *testing.T) {
+	ob := orderbook.NewOrderBook()
+	mustSubmit(t, ob, orderbook.NewLimitOrder(false, fp(20), fp(10_000), orderbook.GTC))
+	mustSubmit(t, ob, orderbook.NewLimitOrder(true, fp(20), fp(9_000), orderbook.GTC))
+
+	fm := NewFlowMeter(ob, 1_000)
+
+	// A 9-unit buy-aggressor fill followed by a larger 10-unit
+	// sell-aggressor fill is net sell-side, but OFI is a ratio: it must
+	// stay within [-1, 1] rather than growing with trade size.
+	mustSubmit(t, ob, orderbook.NewMarketOrder(true, fp(9)))
+	mustSubmit(t, ob, orderbook.NewMarketOrder(false, fp(10)))
+
+	// OFI is 1 after the buy fill alone; it only turns negative once the
+	// sell fill has been recorded too.
+	ofi := waitForCondition(t, func() (float64, bool) {
+		v := fm.OFI()
+		return v, v < 0
+	})
+	want := (9.0 - 10.0) / (9.0 + 10.0)
+	if absFloat(ofi-want) > 1e-9 {
+		t.Errorf("expected OFI %v, got %v", want, ofi)
+	}
+}
+
+func TestFlowMeterVPINAccumulatesOverBucket(t *testing.T) {
+	ob := orderbook.NewOrderBook()
+	mustSubmit(t, ob, orderbook.NewLimitOrder(false, fp(20), fp(10_000), orderbook.GTC))
+
+	fm := NewFlowMeter(ob, 10)
+	mustSubmit(t, ob, orderbook.NewMarketOrder(true, fp(10)))
+
+	vpin := waitForCondition(t, func() (float64, bool) {
+		v := fm.VPIN()
+		return v, v != 0
+	})
+	if vpin != 1 {
+		t.Errorf("expected a fully one-sided 10-volume bucket to report VPIN 1, got %v", vpin)
+	}
+}
+
+func mustSubmit(t *testing.T, ob *orderbook.OrderBook, o *orderbook.Order) {
+	t.Helper()
+	if _, err := ob.Submit(o); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+}
+
+// waitForCondition polls check until it reports ready, returning its value,
+// or fails the test after a second of no progress.
+func waitForCondition(t *testing.T, check func() (float64, bool)) float64 {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if v, ok := check(); ok {
+			return v
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
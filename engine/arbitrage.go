@@ -0,0 +1,198 @@
+package engine
+
+import (
+	"github.com/oluwadamilarey/crypto-trading-engine/fixedpoint"
+	"github.com/oluwadamilarey/crypto-trading-engine/orderbook"
+)
+
+// Leg is one hop of a triangular path. Sell indicates the hop disposes of
+// the asset held entering the hop by hitting the book's best bid; otherwise
+// the hop acquires the next asset in the cycle by lifting the best ask.
+type Leg struct {
+	Symbol string
+	Sell   bool
+}
+
+// Path is a cycle of legs that, traversed in order, returns to the asset it
+// started with, e.g. [BTCUSDT, ETHBTC, ETHUSDT] bought, bought, sold.
+type Path struct {
+	Legs [3]Leg
+}
+
+// ArbitrageOpportunity is a detected round-trip whose ratio clears
+// MinSpreadRatio, bounded by the depth available at the top of each leg's
+// book.
+type ArbitrageOpportunity struct {
+	Path  Path
+	Ratio float64
+	// LegSizes is the order size to submit for each leg, in that leg's own
+	// base asset. Consecutive legs trade different assets, so these are
+	// not the same number repeated: each is the previous leg's output
+	// converted through that leg's own price, then bounded by its book.
+	LegSizes [3]float64
+}
+
+// ArbitrageScanner watches a fixed set of triangular paths across the
+// engine's books and reports (and optionally executes) round trips whose
+// product of best bid/ask ratios, net of fees, clears MinSpreadRatio.
+type ArbitrageScanner struct {
+	engine *Engine
+	paths  []Path
+
+	// MinSpreadRatio is the minimum round-trip ratio (> 1.0 means profit)
+	// required before an opportunity is reported.
+	MinSpreadRatio float64
+	// FeePerLeg is the proportional taker fee charged on each leg, applied
+	// to the leg's price before it is folded into the ratio.
+	FeePerLeg float64
+	// MaxLegSize caps the quantity of the path's starting asset risked on
+	// the first leg, in addition to the depth bound taken from the top of
+	// each book. It carries forward through the remaining legs (converted
+	// through each leg's own price), so it indirectly bounds every leg.
+	MaxLegSize float64
+	// AutoSubmit, when true, immediately executes an opportunity as three
+	// market orders (see execute) in addition to reporting it.
+	AutoSubmit bool
+
+	opportunities chan ArbitrageOpportunity
+}
+
+// NewArbitrageScanner registers a scanner against e for the given paths and
+// starts watching for mutations on any symbol they reference.
+func NewArbitrageScanner(e *Engine, paths []Path, minSpreadRatio, feePerLeg float64) *ArbitrageScanner {
+	s := &ArbitrageScanner{
+		engine:         e,
+		paths:          paths,
+		MinSpreadRatio: minSpreadRatio,
+		FeePerLeg:      feePerLeg,
+		opportunities:  make(chan ArbitrageOpportunity, 64),
+	}
+	e.registerScanner(s)
+
+	return s
+}
+
+// Opportunities returns the channel opportunities are published on.
+func (s *ArbitrageScanner) Opportunities() <-chan ArbitrageOpportunity {
+	return s.opportunities
+}
+
+// scan re-evaluates every path that touches one of the given symbols.
+func (s *ArbitrageScanner) scan(symbols []string) {
+	for _, path := range s.paths {
+		if !pathTouches(path, symbols) {
+			continue
+		}
+
+		opp, ok := s.evaluate(path)
+		if !ok {
+			continue
+		}
+
+		select {
+		case s.opportunities <- opp:
+		default:
+			// Slow consumer; drop rather than block the engine's event loop.
+		}
+
+		if s.AutoSubmit {
+			s.execute(opp)
+		}
+	}
+}
+
+func pathTouches(path Path, symbols []string) bool {
+	for _, leg := range path.Legs {
+		for _, symbol := range symbols {
+			if leg.Symbol == symbol {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// evaluate computes the round-trip ratio and, leg by leg, the size it can be
+// executed at using only the top of each leg's book. A Sell leg multiplies
+// the running ratio by the best bid (net of fee); a buy leg divides it by
+// the best ask (grossed up by fee), since acquiring the next asset costs
+// more per unit.
+//
+// size tracks the quantity carried out of the previous leg, denominated in
+// that leg's own base asset. A buy leg spends that quantity as the quote
+// asset of its own symbol, so it must be divided through the leg's price to
+// land in this leg's base asset before it can be bounded against the book
+// or carried forward; a sell leg disposes of the base asset it was already
+// carrying, so no conversion is needed before bounding.
+func (s *ArbitrageScanner) evaluate(path Path) (ArbitrageOpportunity, bool) {
+	ratio := 1.0
+	size := s.MaxLegSize
+
+	var legSizes [3]float64
+	for i, leg := range path.Legs {
+		ob, ok := s.engine.Book(leg.Symbol)
+		if !ok {
+			return ArbitrageOpportunity{}, false
+		}
+
+		if leg.Sell {
+			bids := ob.Bids()
+			if len(bids) == 0 {
+				return ArbitrageOpportunity{}, false
+			}
+			ratio *= bids[0].Price.Float64() * (1 - s.FeePerLeg)
+			size = minSize(size, bids[0].TotalVolume.Float64())
+		} else {
+			asks := ob.Asks()
+			if len(asks) == 0 {
+				return ArbitrageOpportunity{}, false
+			}
+			price := asks[0].Price.Float64()
+			ratio /= price * (1 + s.FeePerLeg)
+			if size > 0 {
+				size /= price
+			}
+			size = minSize(size, asks[0].TotalVolume.Float64())
+		}
+		legSizes[i] = size
+	}
+
+	if ratio <= s.MinSpreadRatio {
+		return ArbitrageOpportunity{}, false
+	}
+
+	return ArbitrageOpportunity{Path: path, Ratio: ratio, LegSizes: legSizes}, true
+}
+
+func minSize(a, b float64) float64 {
+	if a <= 0 {
+		return b
+	}
+	if b < a {
+		return b
+	}
+	return a
+}
+
+// execute submits the opportunity's three legs as a single atomic batch of
+// market orders. A market order never rests: each leg either fills in full
+// against resting liquidity or is rejected with
+// orderbook.ErrInsufficientLiquidity, so no leg fills partially, and a
+// rejected leg does not undo the legs applied before it.
+//
+// This runs via scanTouched at the end of Engine.run's loop, i.e. already on
+// run()'s own goroutine, so it applies the legs directly rather than
+// round-tripping through executeLegs: sending the legs back onto
+// e.commands from here would block run() forever on its own channel.
+func (s *ArbitrageScanner) execute(opp ArbitrageOpportunity) []legResult {
+	legs := make([]orderCmd, len(opp.Path.Legs))
+	for i, leg := range opp.Path.Legs {
+		legs[i] = orderCmd{
+			symbol: leg.Symbol,
+			order:  orderbook.NewMarketOrder(!leg.Sell, fixedpoint.FromFloat(opp.LegSizes[i])),
+		}
+	}
+
+	results, _ := s.engine.applyLegs(legs)
+	return results
+}
@@ -0,0 +1,158 @@
+// Package engine routes orders for multiple symbols through a single event
+// loop so that every *orderbook.OrderBook in the venue is mutated from one
+// goroutine, giving callers (like ArbitrageScanner) a consistent view of the
+// whole venue between mutations.
+package engine
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/oluwadamilarey/crypto-trading-engine/orderbook"
+)
+
+// orderCmd is one leg of a command: an order bound for a specific symbol's
+// book, submitted as-is via orderbook.OrderBook.Submit.
+type orderCmd struct {
+	symbol string
+	order  *orderbook.Order
+}
+
+// legResult is the outcome of submitting a single leg.
+type legResult struct {
+	matches []orderbook.Match
+	err     error
+}
+
+// command is routed through Engine.commands and processed atomically by the
+// run loop: every leg in a single command is applied before the next command
+// is picked up, which is what lets Engine execute a triangular arbitrage
+// across three books without another order interleaving.
+type command struct {
+	legs []orderCmd
+	done chan []legResult
+}
+
+// Engine owns every order book in the venue and serializes all mutations
+// through a single command channel.
+type Engine struct {
+	mu       sync.RWMutex
+	books    map[string]*orderbook.OrderBook
+	commands chan command
+
+	scannersMu sync.RWMutex
+	scanners   []*ArbitrageScanner
+}
+
+func NewEngine() *Engine {
+	e := &Engine{
+		books:    make(map[string]*orderbook.OrderBook),
+		commands: make(chan command),
+	}
+	go e.run()
+
+	return e
+}
+
+func (e *Engine) run() {
+	for cmd := range e.commands {
+		results, touched := e.applyLegs(cmd.legs)
+		cmd.done <- results
+		e.scanTouched(touched)
+	}
+}
+
+// applyLegs submits every leg directly against its book. It assumes it is
+// already running on the engine's single serializing goroutine: run()
+// itself satisfies that by construction, and so does ArbitrageScanner.
+// execute, since AutoSubmit only ever fires from scanTouched at the end of
+// run()'s loop. Anything else must go through executeLegs instead, which
+// routes through e.commands so run() is the one applying the legs.
+func (e *Engine) applyLegs(legs []orderCmd) ([]legResult, []string) {
+	results := make([]legResult, len(legs))
+	touched := make([]string, 0, len(legs))
+
+	for i, leg := range legs {
+		ob := e.bookFor(leg.symbol)
+		matches, err := ob.Submit(leg.order)
+		results[i] = legResult{matches: matches, err: err}
+		touched = append(touched, leg.symbol)
+	}
+
+	return results, touched
+}
+
+// AddBook registers a new, empty order book for symbol. It panics if the
+// symbol is already registered, mirroring the fail-fast style the rest of
+// this package uses for programmer errors.
+func (e *Engine) AddBook(symbol string) *orderbook.OrderBook {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.books[symbol]; ok {
+		panic(fmt.Errorf("engine: book for symbol [%s] already exists", symbol))
+	}
+
+	ob := orderbook.NewOrderBook()
+	e.books[symbol] = ob
+
+	return ob
+}
+
+// Book returns the order book for symbol, if one has been added.
+func (e *Engine) Book(symbol string) (*orderbook.OrderBook, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	ob, ok := e.books[symbol]
+	return ob, ok
+}
+
+func (e *Engine) bookFor(symbol string) *orderbook.OrderBook {
+	e.mu.RLock()
+	ob, ok := e.books[symbol]
+	e.mu.RUnlock()
+
+	if !ok {
+		panic(fmt.Errorf("engine: no book registered for symbol [%s]", symbol))
+	}
+
+	return ob
+}
+
+// Submit routes o to symbol's book through the engine's event loop and
+// returns the resulting matches, mirroring orderbook.OrderBook.Submit.
+func (e *Engine) Submit(symbol string, o *orderbook.Order) ([]orderbook.Match, error) {
+	results := e.executeLegs([]orderCmd{{symbol: symbol, order: o}})
+	return results[0].matches, results[0].err
+}
+
+// executeLegs submits every leg as a single atomic command from outside
+// run()'s goroutine, so its legs are applied back-to-back with no other
+// order able to land on any of their books in between. Callers already on run()'s goroutine must call
+// applyLegs directly instead: sending here from inside run() would block
+// forever waiting for the very goroutine that is doing the sending.
+func (e *Engine) executeLegs(legs []orderCmd) []legResult {
+	done := make(chan []legResult, 1)
+	e.commands <- command{legs: legs, done: done}
+	return <-done
+}
+
+func (e *Engine) registerScanner(s *ArbitrageScanner) {
+	e.scannersMu.Lock()
+	defer e.scannersMu.Unlock()
+
+	e.scanners = append(e.scanners, s)
+}
+
+// scanTouched re-evaluates every scanner path that trades one of the
+// just-mutated symbols.
+func (e *Engine) scanTouched(symbols []string) {
+	e.scannersMu.RLock()
+	scanners := append([]*ArbitrageScanner{}, e.scanners...)
+	e.scannersMu.RUnlock()
+
+	for _, s := range scanners {
+		s.scan(symbols)
+	}
+}
@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oluwadamilarey/crypto-trading-engine/fixedpoint"
+	"github.com/oluwadamilarey/crypto-trading-engine/orderbook"
+)
+
+func fp(f float64) fixedpoint.Value {
+	return fixedpoint.FromFloat(f)
+}
+
+func TestEngineSubmitLimitOrder(t *testing.T) {
+	e := NewEngine()
+	ob := e.AddBook("BTCUSDT")
+
+	if _, err := e.Submit("BTCUSDT", orderbook.NewLimitOrder(true, fp(1), fp(10_000), orderbook.GTC)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ob.Bids()) != 1 {
+		t.Errorf("expected 1 resting bid, got %d", len(ob.Bids()))
+	}
+}
+
+func TestArbitrageScannerDetectsOpportunity(t *testing.T) {
+	e := NewEngine()
+	btcusdt := e.AddBook("BTCUSDT")
+	ethbtc := e.AddBook("ETHBTC")
+	ethusdt := e.AddBook("ETHUSDT")
+
+	// Sell 1 BTC for 20,000 USDT, buy 10 ETH per BTC, sell ETH at 2,100 USDT
+	// each: round trip nets 21,000 USDT on 20,000 USDT, a clear opportunity.
+	mustSubmit(t, btcusdt, orderbook.NewLimitOrder(true, fp(1), fp(20_000), orderbook.GTC))
+	mustSubmit(t, ethbtc, orderbook.NewLimitOrder(false, fp(10), fp(0.1), orderbook.GTC))
+	mustSubmit(t, ethusdt, orderbook.NewLimitOrder(true, fp(10), fp(2_100), orderbook.GTC))
+
+	path := Path{Legs: [3]Leg{
+		{Symbol: "BTCUSDT", Sell: true},
+		{Symbol: "ETHBTC", Sell: false},
+		{Symbol: "ETHUSDT", Sell: true},
+	}}
+
+	scanner := NewArbitrageScanner(e, []Path{path}, 1.0, 0)
+	scanner.scan([]string{"BTCUSDT", "ETHBTC", "ETHUSDT"})
+
+	select {
+	case opp := <-scanner.Opportunities():
+		if opp.Ratio <= 1.0 {
+			t.Errorf("expected a profitable ratio, got %f", opp.Ratio)
+		}
+		// The 1 BTC bound from the BTCUSDT leg buys 10 ETH at 0.1 BTC each,
+		// and that 10 ETH is what the ETHUSDT leg sells; each leg's size is
+		// in its own base asset, not a single figure copied across legs.
+		want := [3]float64{1, 10, 10}
+		if opp.LegSizes != want {
+			t.Errorf("expected leg sizes %v, got %v", want, opp.LegSizes)
+		}
+	default:
+		t.Fatal("expected an arbitrage opportunity to be published")
+	}
+}
+
+// TestArbitrageScannerAutoSubmitConvertsSizePerLeg exercises AutoSubmit end
+// to end through Engine.Submit, the path a real venue client uses: resting
+// liquidity lands via the engine's event loop, which is what triggers
+// AutoSubmit's execute from inside run() itself, rather than a test calling
+// the unexported scan directly from outside. Each leg's market order must
+// be sized in that leg's own base asset, not the raw bound carried over
+// from the first leg, or the ETHBTC and ETHUSDT legs below would only
+// trade 1 ETH and leave 9 resting.
+func TestArbitrageScannerAutoSubmitConvertsSizePerLeg(t *testing.T) {
+	e := NewEngine()
+	btcusdt := e.AddBook("BTCUSDT")
+	ethbtc := e.AddBook("ETHBTC")
+	ethusdt := e.AddBook("ETHUSDT")
+
+	path := Path{Legs: [3]Leg{
+		{Symbol: "BTCUSDT", Sell: true},
+		{Symbol: "ETHBTC", Sell: false},
+		{Symbol: "ETHUSDT", Sell: true},
+	}}
+
+	scanner := NewArbitrageScanner(e, []Path{path}, 1.0, 0)
+	scanner.AutoSubmit = true
+
+	done := make(chan error, 1)
+	go func() {
+		if _, err := e.Submit("BTCUSDT", orderbook.NewLimitOrder(true, fp(1), fp(20_000), orderbook.GTC)); err != nil {
+			done <- err
+			return
+		}
+		if _, err := e.Submit("ETHBTC", orderbook.NewLimitOrder(false, fp(10), fp(0.1), orderbook.GTC)); err != nil {
+			done <- err
+			return
+		}
+		_, err := e.Submit("ETHUSDT", orderbook.NewLimitOrder(true, fp(10), fp(2_100), orderbook.GTC))
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Engine.Submit did not return; AutoSubmit's execute likely deadlocked run()'s own goroutine")
+	}
+
+	// run() applies AutoSubmit's legs (via scanTouched) before looping back
+	// to receive its next command, so a Submit that round-trips after the
+	// one above is guaranteed to observe the AutoSubmit legs already
+	// applied — and, if AutoSubmit ever regressed to round-tripping through
+	// e.commands instead of applying its legs directly, would hang here
+	// instead, since that would wedge run() on its own channel send.
+	unrelated := make(chan error, 1)
+	go func() {
+		_, err := e.Submit("BTCUSDT", orderbook.NewLimitOrder(true, fp(1), fp(21_000), orderbook.GTC))
+		unrelated <- err
+	}()
+
+	select {
+	case err := <-unrelated:
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("engine is wedged after AutoSubmit: a later Submit never returned")
+	}
+
+	if len(btcusdt.Bids()) != 1 || btcusdt.Bids()[0].Price.Cmp(fp(21_000)) != 0 {
+		t.Errorf("expected only the unrelated 21,000 BTCUSDT bid resting, got %+v", btcusdt.Bids())
+	}
+	if len(ethbtc.Asks()) != 0 {
+		t.Errorf("expected ETHBTC ask fully filled, got %d levels left", len(ethbtc.Asks()))
+	}
+	if len(ethusdt.Bids()) != 0 {
+		t.Errorf("expected ETHUSDT bid fully filled, got %d levels left", len(ethusdt.Bids()))
+	}
+}
+
+func mustSubmit(t *testing.T, ob *orderbook.OrderBook, o *orderbook.Order) {
+	t.Helper()
+	if _, err := ob.Submit(o); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+// Package fixedpoint provides a fixed-point decimal Value for prices and
+// sizes, avoiding the rounding and exact-equality pitfalls of float64 in the
+// order book and matching engine.
+package fixedpoint
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Scale is the factor a Value's mantissa is scaled by (10^8, i.e. 8 decimal
+// places); every Value is an int64 mantissa of hundred-millionths.
+const Scale = 100_000_000
+
+// Value is a fixed-point decimal number with 8 decimal places of precision,
+// stored as an int64 mantissa scaled by Scale. The zero Value is 0.
+type Value struct {
+	mantissa int64
+}
+
+// Zero is the additive identity.
+var Zero = Value{}
+
+// New returns the Value for the integer i.
+func New(i int64) Value {
+	return Value{mantissa: i * Scale}
+}
+
+// FromFloat converts f to the nearest Value representable at 8 decimal
+// places. Intended for constructing test fixtures and literals; values that
+// come from untrusted input should be parsed as decimal strings instead.
+func FromFloat(f float64) Value {
+	return Value{mantissa: int64(f*Scale + sign(f)*0.5)}
+}
+
+func sign(f float64) float64 {
+	if f < 0 {
+		return -1
+	}
+	return 1
+}
+
+func (v Value) Add(o Value) Value {
+	return Value{mantissa: v.mantissa + o.mantissa}
+}
+
+func (v Value) Sub(o Value) Value {
+	return Value{mantissa: v.mantissa - o.mantissa}
+}
+
+// Mul returns v*o, rounded to the nearest representable Value. The
+// intermediate product is computed in big.Int to avoid overflowing int64
+// when multiplying two scaled mantissas.
+func (v Value) Mul(o Value) Value {
+	product := new(big.Int).Mul(big.NewInt(v.mantissa), big.NewInt(o.mantissa))
+	return Value{mantissa: divRound(product, big.NewInt(Scale))}
+}
+
+// Div returns v/o, rounded to the nearest representable Value. It panics on
+// division by zero, matching the behavior of integer division.
+func (v Value) Div(o Value) Value {
+	if o.mantissa == 0 {
+		panic("fixedpoint: division by zero")
+	}
+	numerator := new(big.Int).Mul(big.NewInt(v.mantissa), big.NewInt(Scale))
+	return Value{mantissa: divRound(numerator, big.NewInt(o.mantissa))}
+}
+
+// divRound divides n by d and rounds half away from zero.
+func divRound(n, d *big.Int) int64 {
+	quo, rem := new(big.Int).QuoRem(n, d, new(big.Int))
+
+	rem.Abs(rem)
+	rem.Lsh(rem, 1)
+	if rem.CmpAbs(new(big.Int).Abs(d)) >= 0 {
+		if (n.Sign() < 0) != (d.Sign() < 0) {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	return quo.Int64()
+}
+
+// Cmp returns -1, 0 or +1 depending on whether v is less than, equal to, or
+// greater than o.
+func (v Value) Cmp(o Value) int {
+	switch {
+	case v.mantissa < o.mantissa:
+		return -1
+	case v.mantissa > o.mantissa:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Mod returns the remainder of v divided by o, e.g. to test whether v is an
+// exact multiple of a tick or step size via Mod(o).IsZero(). It panics on
+// division by zero.
+func (v Value) Mod(o Value) Value {
+	if o.mantissa == 0 {
+		panic("fixedpoint: division by zero")
+	}
+	return Value{mantissa: v.mantissa % o.mantissa}
+}
+
+// IsZero reports whether v is exactly zero.
+func (v Value) IsZero() bool {
+	return v.mantissa == 0
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of v.
+func (v Value) Sign() int {
+	return v.Cmp(Zero)
+}
+
+// Float64 converts v to a float64, for interop with code that is not yet
+// fixed-point aware (e.g. logging, metrics). Lossy for values that need more
+// than float64's mantissa precision.
+func (v Value) Float64() float64 {
+	return float64(v.mantissa) / Scale
+}
+
+// String renders v with up to 8 decimal places, dropping trailing zeros and
+// the decimal point entirely for whole numbers.
+func (v Value) String() string {
+	neg := v.mantissa < 0
+	mantissa := v.mantissa
+	if neg {
+		mantissa = -mantissa
+	}
+
+	whole := mantissa / Scale
+	frac := mantissa % Scale
+
+	s := fmt.Sprintf("%d", whole)
+	if frac != 0 {
+		fracStr := fmt.Sprintf("%08d", frac)
+		for len(fracStr) > 0 && fracStr[len(fracStr)-1] == '0' {
+			fracStr = fracStr[:len(fracStr)-1]
+		}
+		s += "." + fracStr
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// MarshalJSON encodes v as a JSON string so callers never round-trip it
+// through a float64 and lose precision.
+func (v Value) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + v.String() + `"`), nil
+}
@@ -0,0 +1,82 @@
+package fixedpoint
+
+import "testing"
+
+func TestAddSub(t *testing.T) {
+	a := FromFloat(10.5)
+	b := FromFloat(3.25)
+
+	if got := a.Add(b).String(); got != "13.75" {
+		t.Errorf("Add: expected 13.75, got %s", got)
+	}
+	if got := a.Sub(b).String(); got != "7.25" {
+		t.Errorf("Sub: expected 7.25, got %s", got)
+	}
+}
+
+func TestMulDiv(t *testing.T) {
+	price := FromFloat(100)
+	size := FromFloat(0.5)
+
+	if got := price.Mul(size).String(); got != "50" {
+		t.Errorf("Mul: expected 50, got %s", got)
+	}
+
+	notional := FromFloat(150)
+	if got := notional.Div(price).String(); got != "1.5" {
+		t.Errorf("Div: expected 1.5, got %s", got)
+	}
+}
+
+func TestMod(t *testing.T) {
+	tickSize := FromFloat(0.01)
+
+	if !FromFloat(1.23).Mod(tickSize).IsZero() {
+		t.Error("expected 1.23 to be an exact multiple of a 0.01 tick size")
+	}
+	if FromFloat(1.235).Mod(tickSize).IsZero() {
+		t.Error("expected 1.235 to not be an exact multiple of a 0.01 tick size")
+	}
+}
+
+func TestCmp(t *testing.T) {
+	a := FromFloat(1.00000001)
+	b := FromFloat(1.00000002)
+
+	if a.Cmp(b) != -1 || b.Cmp(a) != 1 || a.Cmp(a) != 0 {
+		t.Errorf("expected strict ordering at 8 decimal places between %s and %s", a, b)
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	if !New(0).IsZero() {
+		t.Error("expected New(0) to be zero")
+	}
+	if FromFloat(0.00000001).IsZero() {
+		t.Error("expected a nonzero fractional value to not be zero")
+	}
+}
+
+func TestString(t *testing.T) {
+	cases := map[Value]string{
+		New(10):               "10",
+		FromFloat(-2.5):       "-2.5",
+		FromFloat(0.1):        "0.1",
+		FromFloat(0.00000001): "0.00000001",
+	}
+	for v, want := range cases {
+		if got := v.String(); got != want {
+			t.Errorf("String: expected %s, got %s", want, got)
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	b, err := FromFloat(12.5).MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `"12.5"` {
+		t.Errorf(`expected "12.5", got %s`, b)
+	}
+}
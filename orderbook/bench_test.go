@@ -0,0 +1,62 @@
+package orderbook
+
+import "testing"
+
+const benchDepth = 100_000
+
+// seedBook rests benchDepth bid orders spread over 1,000 price levels so the
+// book's tree and per-level linked lists are both under realistic load.
+func seedBook(b *testing.B) (*OrderBook, []*Order) {
+	b.Helper()
+
+	ob := NewOrderBook()
+	orders := make([]*Order, benchDepth)
+	for i := range orders {
+		o := NewLimitOrder(true, fp(1), fp(float64(10_000+i%1_000)), GTC)
+		if _, err := ob.Submit(o); err != nil {
+			b.Fatal(err)
+		}
+		orders[i] = o
+	}
+
+	return ob, orders
+}
+
+func BenchmarkSubmitLimitOrderAt100kDepth(b *testing.B) {
+	ob, _ := seedBook(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		price := fp(float64(10_000 + i%1_000))
+		if _, err := ob.Submit(NewLimitOrder(true, fp(1), price, GTC)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCancelOrderAt100kDepth(b *testing.B) {
+	ob, orders := seedBook(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx := i % benchDepth
+		ob.CancelOrder(orders[idx])
+
+		o := NewLimitOrder(true, fp(1), orders[idx].Price, GTC)
+		if _, err := ob.Submit(o); err != nil {
+			b.Fatal(err)
+		}
+		orders[idx] = o
+	}
+}
+
+func BenchmarkBestBidAt100kDepth(b *testing.B) {
+	ob, _ := seedBook(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if ob.BestBid() == nil {
+			b.Fatal("expected a best bid")
+		}
+	}
+}
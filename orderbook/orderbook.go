@@ -0,0 +1,810 @@
+package orderbook
+
+import (
+	"fmt"
+	"hash/crc32"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/oluwadamilarey/crypto-trading-engine/fixedpoint"
+)
+
+type Match struct {
+	Ask        *Order
+	Bid        *Order
+	SizeFilled fixedpoint.Value
+	Price      fixedpoint.Value
+}
+
+type Order struct {
+	ID        uint64
+	Size      fixedpoint.Value
+	Bid       bool
+	Limit     *Limit
+	Timestamp int64
+
+	// prev and next link this order into its resting Limit's intrusive
+	// doubly-linked list, giving O(1) cancel given a pointer to the order.
+	prev, next *Order
+
+	// Type selects Limit/Market/StopLimit/StopMarket/Iceberg behavior in
+	// OrderBook.Submit. The zero value is OrderTypeLimit.
+	Type OrderType
+	// TIF selects what happens to an unfilled remainder. The zero value is
+	// GTC. Ignored for Type == OrderTypeMarket, which never rests: it fills in
+	// full or is rejected with ErrInsufficientLiquidity.
+	TIF TimeInForce
+	// Price is the limit price for Limit, Iceberg and StopLimit orders.
+	// Unused for Market and StopMarket orders.
+	Price fixedpoint.Value
+	// StopPrice is the last-trade price that promotes a StopLimit/
+	// StopMarket order into a live order.
+	StopPrice fixedpoint.Value
+	// DisplaySize is the visible resting size of an Iceberg order; Size
+	// tracks only the currently displayed chunk.
+	DisplaySize fixedpoint.Value
+
+	// hidden is the undisplayed remainder of an Iceberg order's size,
+	// topped up into Size as each displayed chunk fills.
+	hidden fixedpoint.Value
+}
+
+// orderIDSeq hands out monotonically increasing order IDs so OrderBook can
+// key its orders map without a resting order needing to know its own book.
+var orderIDSeq uint64
+
+func NewOrder(bid bool, size fixedpoint.Value) *Order {
+	return &Order{
+		ID:        atomic.AddUint64(&orderIDSeq, 1),
+		Size:      size,
+		Bid:       bid,
+		Timestamp: time.Now().UnixNano(),
+	}
+}
+
+// NewMarketOrder creates an order that fills immediately against the
+// opposite side of the book and never rests.
+func NewMarketOrder(bid bool, size fixedpoint.Value) *Order {
+	o := NewOrder(bid, size)
+	o.Type = OrderTypeMarket
+	return o
+}
+
+// NewLimitOrder creates a resting order at price with the given
+// time-in-force. tif defaults to GTC when passed as its zero value.
+func NewLimitOrder(bid bool, size, price fixedpoint.Value, tif TimeInForce) *Order {
+	o := NewOrder(bid, size)
+	o.Price = price
+	o.TIF = tif
+	return o
+}
+
+// NewIcebergOrder creates a Limit order that only ever shows displaySize of
+// totalSize on the book, topping the display back up from the hidden
+// remainder after each fill until the reserve is exhausted.
+func NewIcebergOrder(bid bool, totalSize, displaySize, price fixedpoint.Value) *Order {
+	o := NewOrder(bid, displaySize)
+	o.Type = OrderTypeIceberg
+	o.Price = price
+	o.DisplaySize = displaySize
+	o.hidden = totalSize.Sub(displaySize)
+	return o
+}
+
+// NewStopMarketOrder creates a stop order that is submitted as a market
+// order once the book's last trade price crosses triggerPrice.
+func NewStopMarketOrder(bid bool, size, triggerPrice fixedpoint.Value) *Order {
+	o := NewOrder(bid, size)
+	o.Type = OrderTypeStopMarket
+	o.StopPrice = triggerPrice
+	return o
+}
+
+// NewStopLimitOrder creates a stop order that is submitted as a limit order
+// at price once the book's last trade price crosses triggerPrice.
+func NewStopLimitOrder(bid bool, size, price, triggerPrice fixedpoint.Value) *Order {
+	o := NewOrder(bid, size)
+	o.Type = OrderTypeStopLimit
+	o.Price = price
+	o.StopPrice = triggerPrice
+	return o
+}
+
+func (o *Order) String() string {
+	return fmt.Sprintf("[size: %s]", o.Size)
+}
+
+func (o *Order) isFilled() bool {
+	return o.Size.IsZero()
+}
+
+// icebergRefill tops the visible Size back up to DisplaySize from the hidden
+// reserve once the displayed chunk has filled, returning the amount
+// restored, or fixedpoint.Zero if this isn't an iceberg order or its reserve
+// is exhausted.
+func (o *Order) icebergRefill() fixedpoint.Value {
+	if o.Type != OrderTypeIceberg || o.hidden.Sign() <= 0 {
+		return fixedpoint.Zero
+	}
+
+	refill := o.DisplaySize
+	if refill.Cmp(o.hidden) > 0 {
+		refill = o.hidden
+	}
+	o.hidden = o.hidden.Sub(refill)
+	o.Size = refill
+
+	return refill
+}
+
+// Limit holds every resting order at a single price as an intrusive
+// doubly-linked list (oldest at head, newest at tail), giving O(1)
+// price-time-priority add/fill/cancel instead of a scanned slice.
+type Limit struct {
+	Price       fixedpoint.Value
+	TotalVolume fixedpoint.Value
+
+	head, tail *Order
+	count      int
+}
+
+func NewLimit(price fixedpoint.Value) *Limit {
+	return &Limit{Price: price}
+}
+
+// Len returns the number of orders resting at this limit.
+func (l *Limit) Len() int {
+	return l.count
+}
+
+func (l *Limit) AddOrder(o *Order) {
+	o.Limit = l
+	o.prev = l.tail
+	o.next = nil
+
+	if l.tail != nil {
+		l.tail.next = o
+	} else {
+		l.head = o
+	}
+	l.tail = o
+
+	l.count++
+	l.TotalVolume = l.TotalVolume.Add(o.Size)
+}
+
+// DeleteOrder unlinks o from the list in O(1); no scan is required since o
+// already carries pointers to its neighbors.
+func (l *Limit) DeleteOrder(o *Order) {
+	if o.prev != nil {
+		o.prev.next = o.next
+	} else {
+		l.head = o.next
+	}
+	if o.next != nil {
+		o.next.prev = o.prev
+	} else {
+		l.tail = o.prev
+	}
+	o.prev, o.next, o.Limit = nil, nil, nil
+
+	l.count--
+	l.TotalVolume = l.TotalVolume.Sub(o.Size)
+}
+
+// Fill matches o against the resting orders at this limit, best price-time
+// priority first. It returns the resulting matches along with any resting
+// orders that were fully consumed and removed from the book, so the caller
+// can drop them from OrderBook.orders.
+func (l *Limit) Fill(o *Order) ([]Match, []*Order) {
+	var matches []Match
+	var removed []*Order
+
+	for order := l.head; order != nil && !o.isFilled(); {
+		match := l.fillOrder(order, o)
+		matches = append(matches, match)
+		l.TotalVolume = l.TotalVolume.Sub(match.SizeFilled)
+
+		if !order.isFilled() {
+			order = order.next
+			continue
+		}
+
+		if refilled := order.icebergRefill(); refilled.Sign() > 0 {
+			l.TotalVolume = l.TotalVolume.Add(refilled)
+			// Keep matching against the same order: it just refilled at this
+			// same position in the list, so o must drain it again before
+			// moving on to whatever rests behind it, let alone a worse price
+			// level.
+			continue
+		}
+
+		next := order.next // order is unlinked below, so capture next first
+		l.DeleteOrder(order)
+		removed = append(removed, order)
+		order = next
+	}
+
+	return matches, removed
+}
+
+// TODO: Add more context to fillorder function, preferably with chatGPT
+func (l *Limit) fillOrder(a, b *Order) Match {
+	var (
+		bid        *Order           // represent the bid order
+		ask        *Order           // represent the ask order
+		sizeFilled fixedpoint.Value // represent the filled size in the match
+	)
+
+	// determine the bid and ask size based on their bid field
+	if a.Bid {
+		bid = a
+		ask = b
+	} else {
+		bid = b
+		ask = a
+	}
+
+	// Compare the sizes of orders 'a' and 'b' to determine the filled size and adjust sizes accordingly
+	if a.Size.Cmp(b.Size) >= 0 {
+		// 'a' has a size greater than or equal to 'b'
+		a.Size = a.Size.Sub(b.Size) // reduce "a" size by "b"  size
+		sizeFilled = b.Size         // record "b" size as the filled size
+		b.Size = fixedpoint.Zero    // set "b" size to zero indicating complete fill or partial fill by "a"
+	} else {
+		b.Size = b.Size.Sub(a.Size) // reduce "b" size by "a" size
+		sizeFilled = a.Size         // record "a" size as the filled size
+		a.Size = fixedpoint.Zero    // set "a" size to zero indicating complete fill or partial fill by "b"
+	}
+
+	return Match{
+		Bid:        bid,
+		Ask:        ask,
+		SizeFilled: sizeFilled,
+		Price:      l.Price,
+	}
+}
+
+// BookUpdate is a single L2 price-level event: either part of the initial
+// snapshot emitted on Subscribe, or an incremental delta pushed after a
+// mutation. Size is the new aggregated volume resting at Price on the given
+// side; a Size of zero means the level has been removed entirely.
+type BookUpdate struct {
+	Sequence uint64
+	Bid      bool
+	Price    fixedpoint.Value
+	Size     fixedpoint.Value
+}
+
+type OrderBook struct {
+	mu sync.RWMutex
+
+	// market holds the exchange filters incoming orders are validated
+	// against. The zero Market disables every check.
+	market Market
+
+	// asks and bids are price-indexed AVL trees giving O(log P) best-price
+	// lookup and insertion instead of a full sort on every read.
+	asks *priceTree
+	bids *priceTree
+
+	// orders indexes every resting order by ID so Cancel is O(log P)
+	// worst-case with no linear scan over price levels.
+	orders map[uint64]*Order
+
+	// Sequence increases by one for every delta published (each price level
+	// changed by a limit order, market order or cancel) so subscribers can
+	// detect gaps in the delta stream.
+	Sequence uint64
+
+	subscribers []chan BookUpdate
+	matchSubs   []chan Match
+
+	// stops holds pending StopLimit/StopMarket orders, separate from the
+	// live book, until the last trade price crosses their trigger.
+	stops []*Order
+	// lastTrade is the price of the most recent match, used to evaluate
+	// stop triggers.
+	lastTrade fixedpoint.Value
+}
+
+func NewOrderBook() *OrderBook {
+	return NewOrderBookForMarket(Market{})
+}
+
+// NewOrderBookForMarket creates an OrderBook that rejects any order
+// violating m's tick/step/min-notional/min-quantity filters.
+func NewOrderBookForMarket(m Market) *OrderBook {
+	return &OrderBook{
+		asks:   &priceTree{},
+		bids:   &priceTree{},
+		orders: make(map[uint64]*Order),
+		market: m,
+	}
+}
+
+// Subscribe registers a new listener and returns a channel that first
+// receives a full snapshot of every resting price level (bids then asks, one
+// BookUpdate per level) followed by a live stream of deltas for every
+// subsequent mutation. The channel is buffered; slow consumers drop deltas
+// rather than block the book.
+func (ob *OrderBook) Subscribe() <-chan BookUpdate {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	bids := ob.bids.Descending()
+	asks := ob.asks.Ascending()
+
+	snapshot := make([]BookUpdate, 0, len(bids)+len(asks))
+	for _, limit := range bids {
+		snapshot = append(snapshot, BookUpdate{Sequence: ob.Sequence, Bid: true, Price: limit.Price, Size: limit.TotalVolume})
+	}
+	for _, limit := range asks {
+		snapshot = append(snapshot, BookUpdate{Sequence: ob.Sequence, Bid: false, Price: limit.Price, Size: limit.TotalVolume})
+	}
+
+	// Size the buffer to hold the whole snapshot plus the live stream's
+	// usual headroom, so emitting the snapshot below can never block while
+	// ob.mu is held: a subscriber with hundreds of resting price levels
+	// must not wedge every other Submit/Cancel/Checksum call on the book.
+	ch := make(chan BookUpdate, len(snapshot)+256)
+	for _, update := range snapshot {
+		ch <- update
+	}
+	ob.subscribers = append(ob.subscribers, ch)
+
+	return ch
+}
+
+// publish bumps the sequence number and fans the resulting delta out to
+// every subscriber. Callers must hold ob.mu.
+func (ob *OrderBook) publish(bid bool, price, size fixedpoint.Value) {
+	ob.Sequence++
+	update := BookUpdate{Sequence: ob.Sequence, Bid: bid, Price: price, Size: size}
+
+	for _, ch := range ob.subscribers {
+		select {
+		case ch <- update:
+		default:
+			// Slow consumer; drop the delta rather than block the book. It
+			// should resync from Checksum() mismatches.
+		}
+	}
+}
+
+// SubscribeMatches registers a new listener and returns a channel that
+// receives every Match produced by a subsequent Submit call. The channel is
+// buffered; slow consumers drop matches rather than block the book.
+func (ob *OrderBook) SubscribeMatches() <-chan Match {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ch := make(chan Match, 256)
+	ob.matchSubs = append(ob.matchSubs, ch)
+	return ch
+}
+
+// publishMatches fans matches out to every match subscriber. Callers must
+// hold ob.mu.
+func (ob *OrderBook) publishMatches(matches []Match) {
+	for _, ch := range ob.matchSubs {
+		for _, m := range matches {
+			select {
+			case ch <- m:
+			default:
+				// Slow consumer; drop the match rather than block the book.
+			}
+		}
+	}
+}
+
+// Checksum computes a CRC32 over the top depth price levels on each side,
+// bids and asks interleaved best-first as "price:size" pairs, so a consumer
+// of the delta stream can detect that it has desynced from the book.
+func (ob *OrderBook) Checksum(depth int) uint32 {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	bids := ob.bids.Descending()
+	asks := ob.asks.Ascending()
+
+	var sb strings.Builder
+	for i := 0; i < depth; i++ {
+		if i < len(bids) {
+			fmt.Fprintf(&sb, "%v:%v", bids[i].Price, bids[i].TotalVolume)
+		}
+		if i < len(asks) {
+			fmt.Fprintf(&sb, "%v:%v", asks[i].Price, asks[i].TotalVolume)
+		}
+	}
+
+	return crc32.ChecksumIEEE([]byte(sb.String()))
+}
+
+// Submit routes o according to its Type and TimeInForce: Market orders fill
+// immediately against the opposite side; Limit and Iceberg orders match what
+// they can and then honor TIF for any remainder; StopLimit/StopMarket orders
+// are held until the book's last trade price crosses their StopPrice. It
+// returns the matches produced, or a typed error if the order could not be
+// accepted as submitted.
+func (ob *OrderBook) Submit(o *Order) ([]Match, error) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if err := ob.market.validate(o); err != nil {
+		return nil, err
+	}
+
+	if o.Type == OrderTypeStopLimit || o.Type == OrderTypeStopMarket {
+		return ob.submitStop(o)
+	}
+
+	var (
+		matches []Match
+		err     error
+	)
+
+	switch {
+	case o.Type == OrderTypeMarket:
+		matches, err = ob.submitMarket(o)
+	case o.TIF == FOK:
+		matches, err = ob.submitFOK(o)
+	case o.TIF == PostOnly:
+		matches, err = ob.submitPostOnly(o)
+	default:
+		matches, err = ob.submitLimit(o)
+	}
+
+	if err == nil {
+		ob.updateLastTrade(matches)
+		ob.publishMatches(matches)
+	}
+
+	return matches, err
+}
+
+// submitMarket fills o immediately against the opposite side of the book,
+// walking every price level with no limit-price bound.
+func (ob *OrderBook) submitMarket(o *Order) ([]Match, error) {
+	if o.Size.Cmp(ob.availableVolume(o, false)) > 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+	return ob.match(o, false), nil
+}
+
+// submitLimit fills o against price levels at or better than its limit
+// price, then either rests or discards the remainder depending on TIF.
+func (ob *OrderBook) submitLimit(o *Order) ([]Match, error) {
+	matches := ob.match(o, true)
+
+	if o.TIF == IOC {
+		o.Size = fixedpoint.Zero // discard the unfilled remainder instead of resting it
+		return matches, nil
+	}
+
+	if !o.isFilled() {
+		ob.rest(o)
+	}
+
+	return matches, nil
+}
+
+// submitFOK rejects o outright unless its full size can be filled at
+// limit-or-better (or, for a market FOK, at any price) without touching the
+// book.
+func (ob *OrderBook) submitFOK(o *Order) ([]Match, error) {
+	bounded := o.Type != OrderTypeMarket
+	if o.Size.Cmp(ob.availableVolume(o, bounded)) > 0 {
+		return nil, ErrFillOrKill
+	}
+	return ob.match(o, bounded), nil
+}
+
+// submitPostOnly rests o as a maker, rejecting it if its price would cross
+// the opposite side of the book.
+func (ob *OrderBook) submitPostOnly(o *Order) ([]Match, error) {
+	levels := ob.asksLocked()
+	if !o.Bid {
+		levels = ob.bidsLocked()
+	}
+
+	if len(levels) > 0 {
+		best := levels[0].Price
+		if (o.Bid && best.Cmp(o.Price) <= 0) || (!o.Bid && best.Cmp(o.Price) >= 0) {
+			return nil, ErrWouldCross
+		}
+	}
+
+	ob.rest(o)
+	return nil, nil
+}
+
+// submitStop parks o in the stops bucket until a later trade promotes it. It
+// is also indexed in ob.orders, like a resting order, so Cancel(o.ID) can
+// find it before it triggers.
+func (ob *OrderBook) submitStop(o *Order) ([]Match, error) {
+	if o.StopPrice.IsZero() {
+		return nil, ErrMissingTriggerPrice
+	}
+	ob.stops = append(ob.stops, o)
+	ob.orders[o.ID] = o
+	return nil, nil
+}
+
+// match fills o against the opposite side of the book, best price first. If
+// bounded is true, o.Price limits how far into the book it will walk;
+// market orders pass bounded=false.
+func (ob *OrderBook) match(o *Order, bounded bool) []Match {
+	var matches []Match
+
+	levels := ob.asksLocked()
+	if !o.Bid {
+		levels = ob.bidsLocked()
+	}
+
+	for _, limit := range levels {
+		if o.isFilled() {
+			break
+		}
+		if bounded && ((o.Bid && limit.Price.Cmp(o.Price) > 0) || (!o.Bid && limit.Price.Cmp(o.Price) < 0)) {
+			break
+		}
+
+		limitMatches, removed := limit.Fill(o)
+		matches = append(matches, limitMatches...)
+		for _, filled := range removed {
+			delete(ob.orders, filled.ID)
+		}
+
+		if limit.Len() == 0 {
+			ob.clearLimit(!o.Bid, limit)
+			ob.publish(!o.Bid, limit.Price, fixedpoint.Zero)
+		} else {
+			ob.publish(!o.Bid, limit.Price, limit.TotalVolume)
+		}
+	}
+
+	return matches
+}
+
+// availableVolume sums the volume resting on the opposite side of the book
+// that o could reach, under the same bound match uses, without mutating
+// anything.
+func (ob *OrderBook) availableVolume(o *Order, bounded bool) fixedpoint.Value {
+	levels := ob.asksLocked()
+	if !o.Bid {
+		levels = ob.bidsLocked()
+	}
+
+	total := fixedpoint.Zero
+	for _, limit := range levels {
+		if bounded && ((o.Bid && limit.Price.Cmp(o.Price) > 0) || (!o.Bid && limit.Price.Cmp(o.Price) < 0)) {
+			break
+		}
+		total = total.Add(limit.TotalVolume)
+	}
+
+	return total
+}
+
+// rest adds o to the book at its limit price, creating the price level if
+// needed, and publishes the resulting delta.
+func (ob *OrderBook) rest(o *Order) {
+	tree := ob.asks
+	if o.Bid {
+		tree = ob.bids
+	}
+
+	limit, ok := tree.Get(o.Price)
+	if !ok {
+		limit = NewLimit(o.Price)
+		tree.Insert(o.Price, limit)
+	}
+
+	limit.AddOrder(o)
+	ob.orders[o.ID] = o
+	ob.publish(o.Bid, limit.Price, limit.TotalVolume)
+}
+
+// updateLastTrade records the price of the most recent match and promotes
+// any stop orders it triggers.
+func (ob *OrderBook) updateLastTrade(matches []Match) {
+	if len(matches) == 0 {
+		return
+	}
+	ob.lastTrade = matches[len(matches)-1].Price
+	ob.triggerStops()
+}
+
+// triggerStops promotes every pending stop order whose trigger the current
+// lastTrade price has crossed, submitting each as a live order and
+// cascading into any further stops that promotion's matches cross.
+func (ob *OrderBook) triggerStops() {
+	var remaining, triggered []*Order
+
+	for _, stop := range ob.stops {
+		if ob.stopCrossed(stop) {
+			triggered = append(triggered, stop)
+		} else {
+			remaining = append(remaining, stop)
+		}
+	}
+	ob.stops = remaining
+
+	for _, stop := range triggered {
+		var matches []Match
+
+		switch stop.Type {
+		case OrderTypeStopMarket:
+			stop.Type = OrderTypeMarket
+			matches, _ = ob.submitMarket(stop)
+		case OrderTypeStopLimit:
+			stop.Type = OrderTypeLimit
+			matches, _ = ob.submitLimit(stop)
+		}
+
+		// rest (called from submitLimit) re-indexes stop in ob.orders if any
+		// size remains; otherwise it did not rest (it filled in full, its IOC
+		// remainder was discarded, or a stop market was rejected for
+		// insufficient liquidity) and must not linger in ob.orders as an
+		// uncancellable entry.
+		if stop.Limit == nil {
+			delete(ob.orders, stop.ID)
+		}
+
+		ob.updateLastTrade(matches)
+		ob.publishMatches(matches)
+	}
+}
+
+func (ob *OrderBook) stopCrossed(stop *Order) bool {
+	if stop.Bid {
+		return ob.lastTrade.Cmp(stop.StopPrice) >= 0
+	}
+	return ob.lastTrade.Cmp(stop.StopPrice) <= 0
+}
+
+// CancelOrder removes a resting order from its limit, clearing the limit
+// from the book if it was the last order at that price, and publishes the
+// resulting delta to every subscriber. It also cancels a StopLimit/
+// StopMarket order that has not yet triggered. It returns ErrOrderNotResting
+// if o is neither resting nor pending: it may already have been filled,
+// already cancelled, or already promoted out of the stops bucket.
+func (ob *OrderBook) CancelOrder(o *Order) error {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	return ob.cancel(o)
+}
+
+// Cancel looks up a resting or pending order by ID and cancels it. It
+// reports whether an order with that ID was found and successfully
+// cancelled.
+func (ob *OrderBook) Cancel(orderID uint64) bool {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	o, ok := ob.orders[orderID]
+	if !ok {
+		return false
+	}
+
+	return ob.cancel(o) == nil
+}
+
+// cancel removes o from wherever it currently lives: its resting limit, or
+// the pending stops bucket if it has not yet triggered. o.Limit is nil for
+// an order that is not resting, whether because it is a pending stop order
+// or because it was already filled or cancelled, so that alone can't
+// distinguish the two; cancelStop does the extra lookup to tell them apart.
+func (ob *OrderBook) cancel(o *Order) error {
+	if o.Limit == nil {
+		return ob.cancelStop(o)
+	}
+
+	limit := o.Limit
+	bid := o.Bid
+	limit.DeleteOrder(o)
+	delete(ob.orders, o.ID)
+
+	if limit.Len() == 0 {
+		ob.clearLimit(bid, limit)
+		ob.publish(bid, limit.Price, fixedpoint.Zero)
+	} else {
+		ob.publish(bid, limit.Price, limit.TotalVolume)
+	}
+
+	return nil
+}
+
+// cancelStop removes o from the pending stops bucket. It returns
+// ErrOrderNotResting if o is not found there, meaning it was never a stop
+// order, has already triggered, or was already cancelled.
+func (ob *OrderBook) cancelStop(o *Order) error {
+	for i, stop := range ob.stops {
+		if stop == o {
+			ob.stops = append(ob.stops[:i], ob.stops[i+1:]...)
+			delete(ob.orders, o.ID)
+			return nil
+		}
+	}
+	return ErrOrderNotResting
+}
+
+func (ob *OrderBook) clearLimit(bid bool, l *Limit) {
+	if bid {
+		ob.bids.Delete(l.Price)
+	} else {
+		ob.asks.Delete(l.Price)
+	}
+}
+
+func (ob *OrderBook) BidTotalVolume() fixedpoint.Value {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	total := fixedpoint.Zero
+	for _, limit := range ob.bids.Ascending() {
+		total = total.Add(limit.TotalVolume)
+	}
+	return total
+}
+
+func (ob *OrderBook) AskTotalVolume() fixedpoint.Value {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	total := fixedpoint.Zero
+	for _, limit := range ob.asks.Ascending() {
+		total = total.Add(limit.TotalVolume)
+	}
+	return total
+}
+
+// Asks returns every ask price level ordered best-first (lowest price
+// first).
+func (ob *OrderBook) Asks() []*Limit {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return ob.asksLocked()
+}
+
+// Bids returns every bid price level ordered best-first (highest price
+// first).
+func (ob *OrderBook) Bids() []*Limit {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return ob.bidsLocked()
+}
+
+// asksLocked is Asks without the lock, for callers that already hold ob.mu.
+func (ob *OrderBook) asksLocked() []*Limit {
+	return ob.asks.Ascending()
+}
+
+// bidsLocked is Bids without the lock, for callers that already hold ob.mu.
+func (ob *OrderBook) bidsLocked() []*Limit {
+	return ob.bids.Descending()
+}
+
+// BestAsk returns the lowest resting ask price level, or nil if the ask
+// side is empty.
+func (ob *OrderBook) BestAsk() *Limit {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return ob.asks.Min()
+}
+
+// BestBid returns the highest resting bid price level, or nil if the bid
+// side is empty.
+func (ob *OrderBook) BestBid() *Limit {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return ob.bids.Max()
+}
@@ -0,0 +1,361 @@
+package orderbook
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oluwadamilarey/crypto-trading-engine/fixedpoint"
+)
+
+func fp(f float64) fixedpoint.Value {
+	return fixedpoint.FromFloat(f)
+}
+
+func TestLimit(t *testing.T) {
+	l := NewLimit(fp(10_000))
+	buyOrderA := NewOrder(true, fp(5))
+	buyOrderB := NewOrder(true, fp(8))
+	buyOrderC := NewOrder(true, fp(10))
+
+	l.AddOrder(buyOrderA)
+	l.AddOrder(buyOrderB)
+	l.AddOrder(buyOrderC)
+
+	l.DeleteOrder(buyOrderB)
+
+	buyOrder := NewOrder(true, fp(5))
+	l.AddOrder(buyOrder)
+	fmt.Println(l)
+}
+
+func TestOrderBook(t *testing.T) {
+	ob := NewOrderBook()
+	buyOrder := NewLimitOrder(true, fp(10), fp(10_000), GTC)
+	if _, err := ob.Submit(buyOrder); err != nil {
+		t.Fatal(err)
+	}
+
+	fmt.Printf("%+v", ob.Bids()[0])
+}
+
+func TestOrderBookSubscribe(t *testing.T) {
+	ob := NewOrderBook()
+	mustSubmit(t, ob, NewLimitOrder(true, fp(10), fp(10_000), GTC))
+
+	updates := ob.Subscribe()
+	snapshot := <-updates
+	if snapshot.Price.Cmp(fp(10_000)) != 0 || snapshot.Size.Cmp(fp(10)) != 0 {
+		t.Errorf("expected snapshot of [price: 10000 size: 10], got %+v", snapshot)
+	}
+
+	mustSubmit(t, ob, NewLimitOrder(true, fp(5), fp(10_000), GTC))
+
+	delta := <-updates
+	if delta.Sequence != ob.Sequence || delta.Size.Cmp(fp(15)) != 0 {
+		t.Errorf("expected delta [seq: %d size: 15], got %+v", ob.Sequence, delta)
+	}
+}
+
+// TestOrderBookSubscribeDeepBook guards against Subscribe wedging the book
+// when a subscriber's snapshot outgrows a fixed-size channel buffer: a book
+// with hundreds of resting price levels must still return from Subscribe
+// promptly, and Submit must not be blocked by a subscriber that hasn't
+// started draining yet.
+func TestOrderBookSubscribeDeepBook(t *testing.T) {
+	ob := NewOrderBook()
+	const levels = 300
+	for i := 0; i < levels; i++ {
+		mustSubmit(t, ob, NewLimitOrder(true, fp(1), fp(float64(1_000+i)), GTC))
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ob.Subscribe()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Subscribe did not return for a book with 300 resting price levels")
+	}
+
+	mustSubmit(t, ob, NewLimitOrder(false, fp(1), fp(1_000), GTC))
+}
+
+func TestOrderBookChecksum(t *testing.T) {
+	a := NewOrderBook()
+	mustSubmit(t, a, NewLimitOrder(true, fp(10), fp(10_000), GTC))
+	mustSubmit(t, a, NewLimitOrder(false, fp(5), fp(9_000), GTC))
+
+	b := NewOrderBook()
+	mustSubmit(t, b, NewLimitOrder(false, fp(5), fp(9_000), GTC))
+	mustSubmit(t, b, NewLimitOrder(true, fp(10), fp(10_000), GTC))
+
+	if a.Checksum(10) != b.Checksum(10) {
+		t.Errorf("expected equal checksums for equivalent books, got %d and %d", a.Checksum(10), b.Checksum(10))
+	}
+}
+
+func TestSubmitIOCCancelsRemainder(t *testing.T) {
+	ob := NewOrderBook()
+	mustSubmit(t, ob, NewLimitOrder(false, fp(5), fp(10_000), GTC))
+
+	matches, err := ob.Submit(NewLimitOrder(true, fp(10), fp(10_000), IOC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].SizeFilled.Cmp(fp(5)) != 0 {
+		t.Errorf("expected a single 5-size match, got %+v", matches)
+	}
+	if len(ob.Bids()) != 0 {
+		t.Errorf("expected the unfilled IOC remainder to be discarded, got %d resting bids", len(ob.Bids()))
+	}
+}
+
+func TestSubmitFOKRejectsWhenUnfillable(t *testing.T) {
+	ob := NewOrderBook()
+	mustSubmit(t, ob, NewLimitOrder(false, fp(5), fp(10_000), GTC))
+
+	order := NewOrder(true, fp(10))
+	order.Price = fp(10_000)
+	order.TIF = FOK
+
+	if _, err := ob.Submit(order); err != ErrFillOrKill {
+		t.Errorf("expected ErrFillOrKill, got %v", err)
+	}
+	if len(ob.Bids()) != 0 {
+		t.Errorf("expected no resting remainder from a rejected FOK order")
+	}
+}
+
+func TestSubmitPostOnlyRejectsCrossingOrder(t *testing.T) {
+	ob := NewOrderBook()
+	mustSubmit(t, ob, NewLimitOrder(false, fp(5), fp(10_000), GTC))
+
+	order := NewOrder(true, fp(5))
+	order.Price = fp(10_000)
+	order.TIF = PostOnly
+
+	if _, err := ob.Submit(order); err != ErrWouldCross {
+		t.Errorf("expected ErrWouldCross, got %v", err)
+	}
+}
+
+func TestSubmitMarketInsufficientLiquidity(t *testing.T) {
+	ob := NewOrderBook()
+	mustSubmit(t, ob, NewLimitOrder(false, fp(5), fp(10_000), GTC))
+
+	if _, err := ob.Submit(NewMarketOrder(true, fp(10))); err != ErrInsufficientLiquidity {
+		t.Errorf("expected ErrInsufficientLiquidity, got %v", err)
+	}
+}
+
+func TestSubmitIcebergRefillsFromReserve(t *testing.T) {
+	ob := NewOrderBook()
+	mustSubmit(t, ob, NewIcebergOrder(false, fp(15), fp(5), fp(10_000)))
+
+	if ob.Asks()[0].TotalVolume.Cmp(fp(5)) != 0 {
+		t.Fatalf("expected only the 5-size display chunk resting, got %v", ob.Asks()[0].TotalVolume)
+	}
+
+	mustSubmit(t, ob, NewMarketOrder(true, fp(5)))
+
+	if len(ob.Asks()) != 1 || ob.Asks()[0].TotalVolume.Cmp(fp(5)) != 0 {
+		t.Errorf("expected the iceberg to refill its display chunk, got %+v", ob.Asks())
+	}
+}
+
+// TestSubmitIcebergRefillDrainsBeforeWorsePriceLevel reproduces a
+// price-time-priority violation: an iceberg's mid-walk refill must be
+// redrained at its own price before the aggressor is allowed to reach a
+// worse level, even though there is enough volume at the iceberg's price
+// alone to fill the aggressor in full.
+func TestSubmitIcebergRefillDrainsBeforeWorsePriceLevel(t *testing.T) {
+	ob := NewOrderBook()
+	mustSubmit(t, ob, NewIcebergOrder(false, fp(100), fp(10), fp(10_000)))
+	mustSubmit(t, ob, NewLimitOrder(false, fp(5), fp(10_000), GTC))
+	mustSubmit(t, ob, NewLimitOrder(false, fp(5), fp(10_000), GTC))
+	mustSubmit(t, ob, NewLimitOrder(false, fp(50), fp(10_001), GTC))
+
+	matches, err := ob.Submit(NewMarketOrder(true, fp(25)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, m := range matches {
+		if m.Price.Cmp(fp(10_000)) != 0 {
+			t.Errorf("expected every fill to land at the iceberg's 10,000 price, got a fill at %v", m.Price)
+		}
+	}
+
+	asks := ob.Asks()
+	if len(asks) != 2 || asks[1].Price.Cmp(fp(10_001)) != 0 || asks[1].TotalVolume.Cmp(fp(50)) != 0 {
+		t.Errorf("expected the worse 10,001 level untouched, got %+v", asks)
+	}
+}
+
+func TestSubmitStopMarketPromotesOnTrigger(t *testing.T) {
+	ob := NewOrderBook()
+	mustSubmit(t, ob, NewLimitOrder(false, fp(5), fp(10_000), GTC))
+
+	stop := NewStopMarketOrder(true, fp(5), fp(10_000))
+	if _, err := ob.Submit(stop); err != nil {
+		t.Fatal(err)
+	}
+	if len(ob.Asks()) == 0 {
+		t.Fatal("stop order should not trade before its trigger is crossed")
+	}
+
+	mustSubmit(t, ob, NewLimitOrder(false, fp(1), fp(10_000), GTC))
+	matches, err := ob.Submit(NewMarketOrder(true, fp(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if matches[0].Price.Cmp(fp(10_000)) != 0 {
+		t.Fatalf("expected the trade that sets the trigger price, got %+v", matches)
+	}
+
+	if len(ob.Asks()) != 0 {
+		t.Errorf("expected the promoted stop market order to have filled, got %+v", ob.Asks())
+	}
+}
+
+func TestCancelByOrderID(t *testing.T) {
+	ob := NewOrderBook()
+	o := NewLimitOrder(true, fp(10), fp(10_000), GTC)
+	mustSubmit(t, ob, o)
+
+	if !ob.Cancel(o.ID) {
+		t.Fatal("expected Cancel to find the resting order")
+	}
+	if len(ob.Bids()) != 0 {
+		t.Errorf("expected no resting bids after cancel, got %d", len(ob.Bids()))
+	}
+	if ob.Cancel(o.ID) {
+		t.Error("expected a second Cancel of the same ID to report not found")
+	}
+}
+
+func TestCancelOrderAlreadyFilledReturnsError(t *testing.T) {
+	ob := NewOrderBook()
+	o := NewLimitOrder(true, fp(5), fp(10_000), GTC)
+	mustSubmit(t, ob, o)
+	mustSubmit(t, ob, NewMarketOrder(false, fp(5)))
+
+	if len(ob.Bids()) != 0 {
+		t.Fatalf("expected the resting bid to be fully filled, got %+v", ob.Bids())
+	}
+	if err := ob.CancelOrder(o); err != ErrOrderNotResting {
+		t.Errorf("expected ErrOrderNotResting for an already-filled order, got %v", err)
+	}
+}
+
+func TestCancelOrderTwiceReturnsError(t *testing.T) {
+	ob := NewOrderBook()
+	o := NewLimitOrder(true, fp(10), fp(10_000), GTC)
+	mustSubmit(t, ob, o)
+
+	if err := ob.CancelOrder(o); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	if err := ob.CancelOrder(o); err != ErrOrderNotResting {
+		t.Errorf("expected ErrOrderNotResting for a second cancel of the same order, got %v", err)
+	}
+}
+
+func TestCancelPendingStopOrder(t *testing.T) {
+	ob := NewOrderBook()
+	stop := NewStopMarketOrder(true, fp(5), fp(10_000))
+	if _, err := ob.Submit(stop); err != nil {
+		t.Fatal(err)
+	}
+
+	if !ob.Cancel(stop.ID) {
+		t.Fatal("expected Cancel to find the pending stop order")
+	}
+
+	// Trigger the price the stop would have fired on; it must not trade
+	// since it was cancelled before promotion.
+	mustSubmit(t, ob, NewLimitOrder(false, fp(5), fp(10_000), GTC))
+	mustSubmit(t, ob, NewMarketOrder(true, fp(1)))
+
+	if len(ob.Asks()) == 0 || ob.Asks()[0].TotalVolume.Cmp(fp(4)) != 0 {
+		t.Errorf("expected the cancelled stop to never trade, got %+v", ob.Asks())
+	}
+	if err := ob.CancelOrder(stop); err != ErrOrderNotResting {
+		t.Errorf("expected ErrOrderNotResting for a second cancel of the same stop, got %v", err)
+	}
+}
+
+func TestCancelOrderPanicsAvoidedForUntriggeredStopLimit(t *testing.T) {
+	ob := NewOrderBook()
+	stop := NewStopLimitOrder(true, fp(5), fp(9_900), fp(10_000))
+	if _, err := ob.Submit(stop); err != nil {
+		t.Fatal(err)
+	}
+
+	// CancelOrder must not panic on an order whose Limit is still nil
+	// because it has never rested.
+	if err := ob.CancelOrder(stop); err != nil {
+		t.Errorf("CancelOrder: %v", err)
+	}
+}
+
+func TestSubmitRejectsInvalidTickSize(t *testing.T) {
+	ob := NewOrderBookForMarket(Market{TickSize: fp(0.01)})
+
+	if _, err := ob.Submit(NewLimitOrder(true, fp(10), fp(10_000.005), GTC)); err != ErrInvalidTickSize {
+		t.Errorf("expected ErrInvalidTickSize, got %v", err)
+	}
+}
+
+func TestSubmitRejectsBelowMinNotional(t *testing.T) {
+	ob := NewOrderBookForMarket(Market{MinNotional: fp(100)})
+
+	if _, err := ob.Submit(NewLimitOrder(true, fp(1), fp(10), GTC)); err != ErrBelowMinNotional {
+		t.Errorf("expected ErrBelowMinNotional, got %v", err)
+	}
+}
+
+func mustSubmit(t *testing.T, ob *OrderBook, o *Order) {
+	t.Helper()
+	if _, err := ob.Submit(o); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+}
+
+// TestOrderBookReadAPIConcurrentWithSubmit exercises Asks/Bids/
+// BidTotalVolume/AskTotalVolume concurrently with Submit under -race: every
+// exported read of the book must take ob.mu like BestAsk/BestBid/Checksum
+// do, since callers such as engine/arbitrage.go read the book from outside
+// Submit.
+func TestOrderBookReadAPIConcurrentWithSubmit(t *testing.T) {
+	ob := NewOrderBook()
+	mustSubmit(t, ob, NewLimitOrder(true, fp(10), fp(10_000), GTC))
+	mustSubmit(t, ob, NewLimitOrder(false, fp(10), fp(10_100), GTC))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			mustSubmit(t, ob, NewLimitOrder(true, fp(1), fp(float64(9_000+i)), GTC))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			ob.Asks()
+			ob.Bids()
+			ob.BidTotalVolume()
+			ob.AskTotalVolume()
+		}
+	}()
+
+	wg.Wait()
+}
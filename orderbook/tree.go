@@ -0,0 +1,228 @@
+package orderbook
+
+import "github.com/oluwadamilarey/crypto-trading-engine/fixedpoint"
+
+// priceNode is a node in an AVL tree keyed by price, used to hold the bids
+// and asks sides of the book so the best price is an O(log P) descent
+// instead of a full sort on every read.
+type priceNode struct {
+	price       fixedpoint.Value
+	limit       *Limit
+	left, right *priceNode
+	height      int
+}
+
+// priceTree is a self-balancing (AVL) binary search tree keyed by price.
+type priceTree struct {
+	root *priceNode
+	size int
+}
+
+func height(n *priceNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func balanceFactor(n *priceNode) int {
+	if n == nil {
+		return 0
+	}
+	return height(n.left) - height(n.right)
+}
+
+func recalcHeight(n *priceNode) {
+	l, r := height(n.left), height(n.right)
+	if l > r {
+		n.height = l + 1
+	} else {
+		n.height = r + 1
+	}
+}
+
+func rotateRight(n *priceNode) *priceNode {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	recalcHeight(n)
+	recalcHeight(l)
+	return l
+}
+
+func rotateLeft(n *priceNode) *priceNode {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	recalcHeight(n)
+	recalcHeight(r)
+	return r
+}
+
+func rebalance(n *priceNode) *priceNode {
+	recalcHeight(n)
+	bf := balanceFactor(n)
+
+	if bf > 1 {
+		if balanceFactor(n.left) < 0 {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	}
+	if bf < -1 {
+		if balanceFactor(n.right) > 0 {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+
+	return n
+}
+
+// Insert adds limit keyed by price, or replaces the limit already stored at
+// that price.
+func (t *priceTree) Insert(price fixedpoint.Value, limit *Limit) {
+	var inserted bool
+	t.root, inserted = insert(t.root, price, limit)
+	if inserted {
+		t.size++
+	}
+}
+
+func insert(n *priceNode, price fixedpoint.Value, limit *Limit) (*priceNode, bool) {
+	if n == nil {
+		return &priceNode{price: price, limit: limit, height: 1}, true
+	}
+
+	var inserted bool
+	switch {
+	case price.Cmp(n.price) < 0:
+		n.left, inserted = insert(n.left, price, limit)
+	case price.Cmp(n.price) > 0:
+		n.right, inserted = insert(n.right, price, limit)
+	default:
+		n.limit = limit
+		return n, false
+	}
+
+	return rebalance(n), inserted
+}
+
+// Delete removes the limit keyed by price, if present.
+func (t *priceTree) Delete(price fixedpoint.Value) {
+	var deleted bool
+	t.root, deleted = remove(t.root, price)
+	if deleted {
+		t.size--
+	}
+}
+
+func remove(n *priceNode, price fixedpoint.Value) (*priceNode, bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var deleted bool
+	switch {
+	case price.Cmp(n.price) < 0:
+		n.left, deleted = remove(n.left, price)
+	case price.Cmp(n.price) > 0:
+		n.right, deleted = remove(n.right, price)
+	default:
+		deleted = true
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+
+		successor := n.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		n.price, n.limit = successor.price, successor.limit
+		n.right, _ = remove(n.right, successor.price)
+	}
+
+	if n == nil {
+		return nil, deleted
+	}
+	return rebalance(n), deleted
+}
+
+// Get returns the limit keyed by price, if present.
+func (t *priceTree) Get(price fixedpoint.Value) (*Limit, bool) {
+	n := t.root
+	for n != nil {
+		switch {
+		case price.Cmp(n.price) < 0:
+			n = n.left
+		case price.Cmp(n.price) > 0:
+			n = n.right
+		default:
+			return n.limit, true
+		}
+	}
+	return nil, false
+}
+
+// Min returns the limit at the lowest price, e.g. the best ask.
+func (t *priceTree) Min() *Limit {
+	n := t.root
+	if n == nil {
+		return nil
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.limit
+}
+
+// Max returns the limit at the highest price, e.g. the best bid.
+func (t *priceTree) Max() *Limit {
+	n := t.root
+	if n == nil {
+		return nil
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.limit
+}
+
+func (t *priceTree) Len() int {
+	return t.size
+}
+
+// Ascending returns every limit ordered from lowest to highest price.
+func (t *priceTree) Ascending() []*Limit {
+	limits := make([]*Limit, 0, t.size)
+	var walk func(n *priceNode)
+	walk = func(n *priceNode) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		limits = append(limits, n.limit)
+		walk(n.right)
+	}
+	walk(t.root)
+	return limits
+}
+
+// Descending returns every limit ordered from highest to lowest price.
+func (t *priceTree) Descending() []*Limit {
+	limits := make([]*Limit, 0, t.size)
+	var walk func(n *priceNode)
+	walk = func(n *priceNode) {
+		if n == nil {
+			return
+		}
+		walk(n.right)
+		limits = append(limits, n.limit)
+		walk(n.left)
+	}
+	walk(t.root)
+	return limits
+}
@@ -0,0 +1,62 @@
+package orderbook
+
+import "testing"
+
+func TestPriceTreeMinMax(t *testing.T) {
+	tree := &priceTree{}
+	prices := []float64{50, 10, 40, 20, 45, 5, 60, 30}
+
+	for _, p := range prices {
+		tree.Insert(fp(p), &Limit{Price: fp(p)})
+	}
+
+	if tree.Min().Price.Cmp(fp(5)) != 0 {
+		t.Errorf("expected min price 5, got %v", tree.Min().Price)
+	}
+	if tree.Max().Price.Cmp(fp(60)) != 0 {
+		t.Errorf("expected max price 60, got %v", tree.Max().Price)
+	}
+	if tree.Len() != len(prices) {
+		t.Errorf("expected %d nodes, got %d", len(prices), tree.Len())
+	}
+}
+
+func TestPriceTreeDeleteRebalances(t *testing.T) {
+	tree := &priceTree{}
+	for _, p := range []float64{50, 10, 40, 20, 45, 5, 60, 30} {
+		tree.Insert(fp(p), &Limit{Price: fp(p)})
+	}
+
+	tree.Delete(fp(50))
+	tree.Delete(fp(10))
+
+	if _, ok := tree.Get(fp(50)); ok {
+		t.Error("expected price 50 to be gone after delete")
+	}
+	if tree.Len() != 6 {
+		t.Errorf("expected 6 nodes remaining, got %d", tree.Len())
+	}
+	if tree.Min().Price.Cmp(fp(5)) != 0 || tree.Max().Price.Cmp(fp(60)) != 0 {
+		t.Errorf("expected min/max unaffected by unrelated deletes, got min=%v max=%v", tree.Min().Price, tree.Max().Price)
+	}
+
+	ascending := tree.Ascending()
+	for i := 1; i < len(ascending); i++ {
+		if ascending[i-1].Price.Cmp(ascending[i].Price) >= 0 {
+			t.Fatalf("Ascending() out of order: %v", ascending)
+		}
+	}
+
+	var checkBalanced func(n *priceNode)
+	checkBalanced = func(n *priceNode) {
+		if n == nil {
+			return
+		}
+		if bf := balanceFactor(n); bf < -1 || bf > 1 {
+			t.Errorf("expected an AVL-balanced tree after delete, node %v has balance factor %d", n.price, bf)
+		}
+		checkBalanced(n.left)
+		checkBalanced(n.right)
+	}
+	checkBalanced(tree.root)
+}
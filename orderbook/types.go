@@ -0,0 +1,113 @@
+package orderbook
+
+import (
+	"errors"
+
+	"github.com/oluwadamilarey/crypto-trading-engine/fixedpoint"
+)
+
+// OrderType distinguishes how an order rests on (or bypasses) the book.
+// Named with an OrderType prefix to avoid colliding with the Limit struct.
+type OrderType int
+
+const (
+	OrderTypeLimit OrderType = iota
+	OrderTypeMarket
+	OrderTypeStopLimit
+	OrderTypeStopMarket
+	OrderTypeIceberg
+)
+
+// TimeInForce controls what happens to the part of an order that does not
+// fill immediately.
+type TimeInForce int
+
+const (
+	// GTC rests any unfilled remainder on the book until cancelled.
+	GTC TimeInForce = iota
+	// IOC fills what it can immediately and cancels the remainder instead
+	// of resting it.
+	IOC
+	// FOK requires the full size to be fillable at limit-or-better before
+	// touching the book; otherwise the order is rejected outright.
+	FOK
+	// PostOnly rejects the order if it would cross the book instead of
+	// resting as a maker.
+	PostOnly
+)
+
+var (
+	// ErrInsufficientLiquidity is returned when a market order's size
+	// exceeds the volume resting on the opposite side of the book.
+	ErrInsufficientLiquidity = errors.New("orderbook: insufficient liquidity to fill order")
+	// ErrFillOrKill is returned when an FOK order cannot be filled in full
+	// at limit-or-better without touching the book.
+	ErrFillOrKill = errors.New("orderbook: fill-or-kill order could not be filled in full")
+	// ErrWouldCross is returned when a PostOnly order's price would cross
+	// the opposite side of the book.
+	ErrWouldCross = errors.New("orderbook: post-only order would cross the book")
+	// ErrMissingTriggerPrice is returned when a stop order is submitted
+	// without a trigger price.
+	ErrMissingTriggerPrice = errors.New("orderbook: stop order requires a non-zero trigger price")
+	// ErrInvalidTickSize is returned when an order's price is not an exact
+	// multiple of its market's TickSize.
+	ErrInvalidTickSize = errors.New("orderbook: price is not a multiple of the market's tick size")
+	// ErrInvalidStepSize is returned when an order's size is not an exact
+	// multiple of its market's StepSize.
+	ErrInvalidStepSize = errors.New("orderbook: size is not a multiple of the market's step size")
+	// ErrBelowMinQuantity is returned when an order's size is smaller than
+	// its market's MinQuantity.
+	ErrBelowMinQuantity = errors.New("orderbook: size is below the market's minimum quantity")
+	// ErrBelowMinNotional is returned when an order's price times size is
+	// smaller than its market's MinNotional.
+	ErrBelowMinNotional = errors.New("orderbook: notional value is below the market's minimum notional")
+	// ErrOrderNotResting is returned by CancelOrder when o is neither resting
+	// on the book nor pending as an untriggered stop order — e.g. it has
+	// already been filled, already cancelled, or is an already-promoted stop
+	// order.
+	ErrOrderNotResting = errors.New("orderbook: order is not resting or pending cancellation")
+)
+
+// Market holds the exchange filters an OrderBook validates incoming orders
+// against before they are matched or rested. The zero Market disables every
+// check, so an OrderBook created with NewOrderBook() behaves as it did
+// before Market existed.
+type Market struct {
+	// TickSize is the minimum price increment; an order's Price must be an
+	// exact multiple of it. Zero disables the check.
+	TickSize fixedpoint.Value
+	// StepSize is the minimum size increment; an order's Size must be an
+	// exact multiple of it. Zero disables the check.
+	StepSize fixedpoint.Value
+	// MinNotional is the minimum Price*Size value for an order that carries
+	// a price. Zero disables the check.
+	MinNotional fixedpoint.Value
+	// MinQuantity is the minimum order Size. Zero disables the check.
+	MinQuantity fixedpoint.Value
+}
+
+// validate rejects o if it violates any of Market's filters. Market and
+// StopMarket orders carry no usable limit price, so TickSize and
+// MinNotional are only checked for order types that rest or trigger at a
+// price.
+func (m Market) validate(o *Order) error {
+	if !m.StepSize.IsZero() && !o.Size.Mod(m.StepSize).IsZero() {
+		return ErrInvalidStepSize
+	}
+	if !m.MinQuantity.IsZero() && o.Size.Cmp(m.MinQuantity) < 0 {
+		return ErrBelowMinQuantity
+	}
+
+	if o.Type == OrderTypeMarket || o.Type == OrderTypeStopMarket {
+		return nil
+	}
+
+	if !m.TickSize.IsZero() && !o.Price.Mod(m.TickSize).IsZero() {
+		return ErrInvalidTickSize
+	}
+	if !m.MinNotional.IsZero() && o.Price.Mul(o.Size).Cmp(m.MinNotional) < 0 {
+		return ErrBelowMinNotional
+	}
+
+	return nil
+}